@@ -0,0 +1,90 @@
+// Package apierror centralizes how request failures become HTTP responses.
+// Handlers return or record one of the typed errors below; Middleware turns
+// whichever one it finds into an RFC 7807 application/problem+json body.
+package apierror
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// Error is a typed API error carrying everything needed to render an RFC
+// 7807 problem document.
+type Error struct {
+	Type   string // a URI identifying the problem type
+	Title  string // short, human-readable summary of the problem type
+	Status int    // HTTP status code
+	Detail string // human-readable explanation specific to this occurrence
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Title, e.Detail)
+}
+
+// NotFound builds the typed error for a missing resource.
+func NotFound(detail string) *Error {
+	return &Error{
+		Type:   "about:blank#not-found",
+		Title:  "Not Found",
+		Status: http.StatusNotFound,
+		Detail: detail,
+	}
+}
+
+// OutOfStock builds the typed error for a checkout against a book with no
+// copies left, including a concurrent checkout that wins the race.
+func OutOfStock(detail string) *Error {
+	return &Error{
+		Type:   "about:blank#out-of-stock",
+		Title:  "Conflict",
+		Status: http.StatusConflict,
+		Detail: detail,
+	}
+}
+
+// Unauthorized builds the typed error for a missing or invalid credential.
+func Unauthorized(detail string) *Error {
+	return &Error{
+		Type:   "about:blank#unauthorized",
+		Title:  "Unauthorized",
+		Status: http.StatusUnauthorized,
+		Detail: detail,
+	}
+}
+
+// Forbidden builds the typed error for a valid credential that lacks the
+// role a route requires.
+func Forbidden(detail string) *Error {
+	return &Error{
+		Type:   "about:blank#forbidden",
+		Title:  "Forbidden",
+		Status: http.StatusForbidden,
+		Detail: detail,
+	}
+}
+
+// Validation builds the typed error for a request that fails field
+// validation.
+func Validation(detail string) *Error {
+	return &Error{
+		Type:   "about:blank#validation",
+		Title:  "Validation Failed",
+		Status: http.StatusBadRequest,
+		Detail: detail,
+	}
+}
+
+// Internal builds the typed error for an unexpected failure, e.g. a store
+// that couldn't be reached. cause is logged server-side so it's not lost,
+// but never appears in Detail: the client only ever sees a generic
+// message, since cause may contain SQL errors, DSNs, or file paths.
+func Internal(cause error) *Error {
+	log.Printf("apierror: internal error: %v", cause)
+	return &Error{
+		Type:   "about:blank#internal",
+		Title:  "Internal Server Error",
+		Status: http.StatusInternalServerError,
+		Detail: "An unexpected error occurred.",
+	}
+}
@@ -0,0 +1,32 @@
+package apierror
+
+import "github.com/gin-gonic/gin"
+
+// Middleware renders the last error recorded on the context (via c.Error)
+// as an RFC 7807 application/problem+json body. Handlers that record a
+// *Error get its status and fields back verbatim; any other error is
+// treated as an opaque internal failure so its message isn't leaked.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		apiErr, ok := err.(*Error)
+		if !ok {
+			apiErr = Internal(err)
+		}
+
+		c.Header("Content-Type", "application/problem+json")
+		c.AbortWithStatusJSON(apiErr.Status, gin.H{
+			"type":     apiErr.Type,
+			"title":    apiErr.Title,
+			"status":   apiErr.Status,
+			"detail":   apiErr.Detail,
+			"instance": c.Request.URL.Path,
+		})
+	}
+}
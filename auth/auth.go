@@ -0,0 +1,62 @@
+// Package auth issues and verifies the JWTs that gate access to the book
+// API, and provides the Gin middleware that enforces them.
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned by Parse for any token that doesn't verify:
+// expired, wrong signature, or malformed.
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// errMissingSigningKey is returned by NewConfigFromEnv when JWT_SIGNING_KEY
+// isn't set.
+var errMissingSigningKey = errors.New("auth: JWT_SIGNING_KEY must be set")
+
+// Config holds the signing key and token lifetime, read from env vars at
+// startup (see NewConfigFromEnv in config.go).
+type Config struct {
+	SigningKey []byte
+	TTL        time.Duration
+}
+
+// Claims is the JWT payload: the standard registered claims plus the role
+// RequireRole checks against.
+type Claims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Issue signs a new JWT for userID with the given role, valid for cfg.TTL.
+func Issue(cfg Config, userID, role string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(cfg.TTL)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(cfg.SigningKey)
+}
+
+// Parse verifies tokenString against cfg.SigningKey and returns its claims.
+func Parse(cfg Config, tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(t *jwt.Token) (interface{}, error) {
+		return cfg.SigningKey, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssueAndParse(t *testing.T) {
+	cfg := Config{SigningKey: []byte("test-secret"), TTL: time.Minute}
+
+	token, err := Issue(cfg, "user-1", "admin")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	claims, err := Parse(cfg, token)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if claims.Subject != "user-1" {
+		t.Errorf("Subject = %q, want %q", claims.Subject, "user-1")
+	}
+	if claims.Role != "admin" {
+		t.Errorf("Role = %q, want %q", claims.Role, "admin")
+	}
+}
+
+func TestParseRejectsWrongKey(t *testing.T) {
+	token, err := Issue(Config{SigningKey: []byte("key-a"), TTL: time.Minute}, "user-1", "user")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if _, err := Parse(Config{SigningKey: []byte("key-b"), TTL: time.Minute}, token); err != ErrInvalidToken {
+		t.Errorf("Parse(wrong key) error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestParseRejectsExpiredToken(t *testing.T) {
+	cfg := Config{SigningKey: []byte("test-secret"), TTL: -time.Minute}
+
+	token, err := Issue(cfg, "user-1", "user")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if _, err := Parse(cfg, token); err != ErrInvalidToken {
+		t.Errorf("Parse(expired) error = %v, want ErrInvalidToken", err)
+	}
+}
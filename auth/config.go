@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// defaultTTL is used when JWT_TTL_MINUTES isn't set or isn't a valid number.
+const defaultTTL = 15 * time.Minute
+
+// NewConfigFromEnv builds a Config from JWT_SIGNING_KEY and
+// JWT_TTL_MINUTES. A missing signing key is an error: there's no safe
+// default to fall back to.
+func NewConfigFromEnv() (Config, error) {
+	key := os.Getenv("JWT_SIGNING_KEY")
+	if key == "" {
+		return Config{}, errMissingSigningKey
+	}
+
+	ttl := defaultTTL
+	if raw := os.Getenv("JWT_TTL_MINUTES"); raw != "" {
+		if minutes, err := strconv.Atoi(raw); err == nil && minutes > 0 {
+			ttl = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	return Config{SigningKey: []byte(key), TTL: ttl}, nil
+}
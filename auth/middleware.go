@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/apierror"
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/users"
+)
+
+// ContextUserIDKey and ContextRoleKey are the gin.Context keys RequireRole
+// stores the authenticated principal under.
+const (
+	ContextUserIDKey = "auth.userID"
+	ContextRoleKey   = "auth.role"
+)
+
+// RequireRole returns middleware that accepts only requests bearing a valid
+// JWT whose role claim is exactly role, or "admin" (admins can do anything
+// a regular user can). Missing or malformed Authorization headers and
+// invalid tokens get 401; a valid token with the wrong role gets 403.
+func RequireRole(cfg Config, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		tokenString, ok := strings.CutPrefix(header, "Bearer ")
+		if header == "" || !ok {
+			c.Error(apierror.Unauthorized("Missing or malformed Authorization header."))
+			c.Abort()
+			return
+		}
+
+		claims, err := Parse(cfg, tokenString)
+		if err != nil {
+			c.Error(apierror.Unauthorized("Invalid or expired token."))
+			c.Abort()
+			return
+		}
+
+		if claims.Role != role && claims.Role != users.RoleAdmin {
+			c.Error(apierror.Forbidden("This action requires the " + role + " role."))
+			c.Abort()
+			return
+		}
+
+		c.Set(ContextUserIDKey, claims.Subject)
+		c.Set(ContextRoleKey, claims.Role)
+		c.Next()
+	}
+}
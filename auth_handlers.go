@@ -0,0 +1,93 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/apierror"
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/auth"
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/users"
+	"github.com/google/uuid"
+)
+
+// userDB is the active users.Store backend.
+var userDB users.Store
+
+// authCfg holds the JWT signing key and TTL, loaded from the environment
+// at startup.
+var authCfg auth.Config
+
+// credentials is the request body for both /auth/register and /auth/login.
+type credentials struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// registerUser handles POST /auth/register. New accounts are always
+// created with the "user" role; granting "admin" is an operator action,
+// not something a client can request for itself.
+// @Summary Register a new account
+// @Description Creates a user account and returns a signed JWT for it.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body credentials true "Username and password"
+// @Success 201 {object} object
+// @Failure 400 {object} apierror.Error
+// @Router /auth/register [post]
+func registerUser(c *gin.Context) {
+	var creds credentials
+	if err := c.ShouldBindJSON(&creds); err != nil {
+		c.Error(apierror.Validation(err.Error()))
+		return
+	}
+
+	u, err := userDB.Create(uuid.NewString(), creds.Username, creds.Password, users.RoleUser)
+	if err != nil {
+		if err == users.ErrDuplicateUsername {
+			c.Error(apierror.Validation("Username is already registered."))
+			return
+		}
+		c.Error(apierror.Internal(err))
+		return
+	}
+
+	token, err := auth.Issue(authCfg, u.ID, u.Role)
+	if err != nil {
+		c.Error(apierror.Internal(err))
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, gin.H{"token": token})
+}
+
+// loginUser handles POST /auth/login.
+// @Summary Log in
+// @Description Authenticates a username/password pair and returns a signed JWT.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body credentials true "Username and password"
+// @Success 200 {object} object
+// @Failure 401 {object} apierror.Error
+// @Router /auth/login [post]
+func loginUser(c *gin.Context) {
+	var creds credentials
+	if err := c.ShouldBindJSON(&creds); err != nil {
+		c.Error(apierror.Validation(err.Error()))
+		return
+	}
+
+	u, err := userDB.Authenticate(creds.Username, creds.Password)
+	if err != nil {
+		c.Error(apierror.Unauthorized("Invalid username or password."))
+		return
+	}
+
+	token, err := auth.Issue(authCfg, u.ID, u.Role)
+	if err != nil {
+		c.Error(apierror.Internal(err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"token": token})
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/docs"
+)
+
+// openAPISpec godoc
+// @Summary OpenAPI spec
+// @Description Returns the generated OpenAPI document for this API.
+// @Tags docs
+// @Produce json
+// @Success 200 {object} object
+// @Router /openapi.json [get]
+func openAPISpec(c *gin.Context) {
+	spec := docs.SwaggerInfo.ReadDoc()
+	c.Data(http.StatusOK, "application/json", []byte(spec))
+}
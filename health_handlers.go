@@ -0,0 +1,38 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/store"
+)
+
+// healthzHandler godoc
+// @Summary Liveness check
+// @Description Always returns 200 once the process is up.
+// @Tags ops
+// @Produce json
+// @Success 200 {object} object
+// @Router /healthz [get]
+func healthzHandler(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// readyzHandler godoc
+// @Summary Readiness check
+// @Description Returns 200 only if the store backend answers a query.
+// @Tags ops
+// @Produce json
+// @Success 200 {object} object
+// @Failure 503 {object} object
+// @Router /readyz [get]
+func readyzHandler(c *gin.Context) {
+	if _, _, err := db.List(store.ListParams{Limit: 1}); err != nil {
+		log.Printf("readyz: store unavailable: %v", err)
+		c.IndentedJSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable"})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -1,37 +1,124 @@
 package main
 
 import (
-	"errors"
+	"context"
+	"flag"
+	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.uber.org/zap"
+
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/apierror"
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/auth"
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/store"
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/telemetry"
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/users"
 )
 
-// fields must start with capital to be an exported field name aka "public field", which means it can be viewed by modules outside of this file
-// `json:"id"` is used to represent the Json field name in the struct, and in Json it will convert the field name to lowercase
-// vice versa, when the Json object is converted to the book struct the field will turn back to start with uppercase
-// because we added the json bits at the back, our book can be serialized easily to JSON
-type book struct {
-	ID       string `json:"id"`
-	Title    string `json:"title"`
-	Author   string `json:"author"`
-	Quantity int    `json:"quantity"`
-}
+// serviceName identifies this service in logs, traces, and metrics.
+const serviceName = "book-api"
+
+// book is an alias for store.Book so handlers can keep referring to "book"
+// the way they always have, even though the data now lives behind a Store.
+type book = store.Book
 
-var books = []book{
-	{ID: "1", Title: "In Search of Lost Time", Author: "Marcel Proust", Quantity: 2},
-	{ID: "2", Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Quantity: 5},
-	{ID: "3", Title: "War and Peace", Author: "Leo Tolstoy", Quantity: 6},
+// db is the active Store backend, selected at startup by --store or STORE_DSN.
+var db store.Store
+
+// toAPIError maps a store error to the apierror.Error the middleware will
+// turn into a problem+json response, falling back to an internal error for
+// anything the store wasn't expected to return.
+func toAPIError(err error) *apierror.Error {
+	switch err {
+	case store.ErrNotFound:
+		return apierror.NotFound("Book not found.")
+	case store.ErrOutOfStock:
+		return apierror.OutOfStock("No more books left.")
+	default:
+		return apierror.Internal(err)
+	}
 }
 
-// handle GET all books
+// getBooks godoc
+// @Summary List books
+// @Description Returns a page of books, optionally filtered, sorted, and paginated.
+// @Tags books
+// @Produce json
+// @Param limit query int false "Max results per page (default 20, max 100)"
+// @Param offset query int false "Number of results to skip"
+// @Param author query string false "Case-insensitive substring match on author"
+// @Param title query string false "Case-insensitive substring match on title"
+// @Param in_stock query bool false "Filter by whether quantity > 0"
+// @Param sort query string false "Comma-separated fields to sort by, prefix with - for descending, e.g. title,-quantity"
+// @Success 200 {object} object
+// @Failure 400 {object} apierror.Error
+// @Router /books [get]
+//
 // this function takes a context (c), which stores all the info about the request such as query parameters, payload, headers
 func getBooks(c *gin.Context) {
+	params, err := store.ParseListParams(c.Request.URL.Query())
+	if err != nil {
+		c.Error(apierror.Validation(err.Error()))
+		return
+	}
+
+	books, total, err := db.List(params)
+	if err != nil {
+		c.Error(toAPIError(err))
+		return
+	}
+
+	page := gin.H{"limit": params.Limit, "offset": params.Offset, "total": total}
+	if next := nextPageURL(c, params, total); next != "" {
+		page["next"] = next
+		c.Header("Link", fmt.Sprintf(`<%s>; rel="next"`, next))
+	}
+
 	// IndentedJSON will format the JSON for us
 	// the HTTP status code will be OK, and the data is the books
-	c.IndentedJSON(http.StatusOK, books)
+	c.IndentedJSON(http.StatusOK, gin.H{"data": books, "page": page})
+}
+
+// nextPageURL returns the absolute URL for the page after params, or "" if
+// params.Offset+params.Limit already covers every match.
+func nextPageURL(c *gin.Context, params store.ListParams, total int) string {
+	nextOffset := params.Offset + params.Limit
+	if nextOffset >= total {
+		return ""
+	}
+
+	q := c.Request.URL.Query()
+	q.Set("limit", strconv.Itoa(params.Limit))
+	q.Set("offset", strconv.Itoa(nextOffset))
+
+	u := *c.Request.URL
+	u.RawQuery = q.Encode()
+	u.Scheme = "http"
+	if c.Request.TLS != nil {
+		u.Scheme = "https"
+	}
+	u.Host = c.Request.Host
+	return u.String()
 }
 
+// bookById godoc
+// @Summary Get a book
+// @Description Returns a single book by ID.
+// @Tags books
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} store.Book
+// @Failure 404 {object} apierror.Error
+// @Router /books/{id} [get]
+//
 // function to bind data in the request context to a book
 func bookById(c *gin.Context) {
 	// Param means that this is a path parameter like "/books/2" where 2 is the id
@@ -40,15 +127,23 @@ func bookById(c *gin.Context) {
 	book, err := getBookById(id)
 
 	if err != nil {
-		// return a custom response saying 404 not found
-		// gin.H is a shortcut to allow us to easily write custom JSON to be returned
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Book not found."})
+		c.Error(toAPIError(err))
 		return
 	}
 
 	c.IndentedJSON(http.StatusOK, book)
 }
 
+// checkoutBook godoc
+// @Summary Check out a book
+// @Description Decrements a book's quantity by one. Requires the user role.
+// @Tags books
+// @Produce json
+// @Security BearerAuth
+// @Param id query string true "Book ID"
+// @Success 200 {object} store.Book
+// @Failure 409 {object} apierror.Error
+// @Router /checkout [patch]
 func checkoutBook(c *gin.Context) {
 	// we check out books by ID
 	// but this time we accept it as a query parameter instead of a path parameter so we can learn how it works
@@ -57,63 +152,67 @@ func checkoutBook(c *gin.Context) {
 
 	// if !ok is basically if ok == false
 	if !ok {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "Missing id query parameter"})
-	}
-
-	book, err := getBookById(id)
-
-	if err != nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Book not found"})
+		c.Error(apierror.Validation("Missing id query parameter."))
 		return
 	}
 
-	// check book quantity. We cannot let it be checked out if quantity is 0
-	if book.Quantity <= 0 {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "No more books left"})
+	book, err := db.Checkout(id)
+	if err != nil {
+		c.Error(toAPIError(err))
 		return
 	}
-
-	// reduce the quantity of a type of book if it was checked out
-	book.Quantity -= 1
-
-	// return the checked out book
+	telemetry.BooksCheckedOutTotal.Inc()
 	c.IndentedJSON(http.StatusOK, book)
 }
 
+// returnBook godoc
+// @Summary Return a book
+// @Description Increments a book's quantity by one. Requires the user role.
+// @Tags books
+// @Produce json
+// @Security BearerAuth
+// @Param id query string true "Book ID"
+// @Success 200 {object} store.Book
+// @Failure 404 {object} apierror.Error
+// @Router /return [patch]
 func returnBook(c *gin.Context) {
 	id, ok := c.GetQuery("id")
 
 	if !ok {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "Missing id query parameter"})
+		c.Error(apierror.Validation("Missing id query parameter."))
+		return
 	}
 
-	book, err := getBookById(id)
-
+	book, err := db.Return(id)
 	if err != nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Book not found"})
+		c.Error(toAPIError(err))
 		return
 	}
-
-	// add the quantity of a type of book if it was returned
-	book.Quantity += 1
 	c.IndentedJSON(http.StatusOK, book)
 }
 
 // helper function to GET one book by ID
 // we return a pointer to a book (*book) and a error because the book might not exist. This is shown by the (*book, error)
 func getBookById(id string) (*book, error) {
-	// loop through all books to look for the right book
-	for i, b := range books {
-		if b.ID == id {
-			// we return &books[i] to get a pointer to the right book, so that we can modify the struct's fields from a different function
-			// we return nil as an error if the right book is found
-			return &books[i], nil
-		}
+	b, err := db.Get(id)
+	if err != nil {
+		return nil, err
 	}
-
-	return nil, errors.New("book not found")
+	return &b, nil
 }
 
+// createBook godoc
+// @Summary Create a book
+// @Description Creates a book. Requires the admin role.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param book body store.Book true "Book"
+// @Success 201 {object} store.Book
+// @Failure 400 {object} apierror.Error
+// @Failure 403 {object} apierror.Error
+// @Router /books [post]
 func createBook(c *gin.Context) {
 	// create a new variable of type book
 	var newBook book
@@ -121,27 +220,212 @@ func createBook(c *gin.Context) {
 	// we need to use something from c to bind the JSON (which was part of the request payload) to the newBook variable
 	// we're passing the pointer (&) to the newBook (which we can directly modify the fields of)
 	// and we check whether we got an error (if the error is != null means we got an error) then we can directly return
-	// rmb: Returning does not automatically return a response. The .BindJSON() method is what will handle sending the error response
-	if err := c.BindJSON(&newBook); err != nil {
-		// enter here if there is an error
+	// rmb: Returning does not automatically return a response. .ShouldBindJSON() only reports the error; we record it with c.Error and apierror.Middleware sends the 400 response.
+	// Gin's validator also reports an error here if the binding tags on book aren't satisfied.
+	if err := c.ShouldBindJSON(&newBook); err != nil {
+		c.Error(apierror.Validation(err.Error()))
 		return
 	}
 
-	// move here if there are no erros, and now we bind the JSON to the newBook struct
-	// which contains all the data that was returned to this endpoint which we can append to the books array (aka slice in Go)
-	books = append(books, newBook)
-	c.IndentedJSON(http.StatusCreated, newBook)
+	// clients don't supply an id; we mint one so two concurrent creates can never collide
+	newBook.ID = uuid.NewString()
+
+	// move here if there are no erros, and now we save the new book to the store
+	created, err := db.Create(newBook)
+	if err != nil {
+		c.Error(toAPIError(err))
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, created)
+}
+
+// updateBook godoc
+// @Summary Replace a book
+// @Description Replaces every field of a book. Requires the admin role.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param book body store.Book true "Book"
+// @Success 200 {object} store.Book
+// @Failure 404 {object} apierror.Error
+// @Router /books/{id} [put]
+//
+// updateBook handles PUT /books/:id, replacing every field of the book.
+func updateBook(c *gin.Context) {
+	var updated book
+	if err := c.ShouldBindJSON(&updated); err != nil {
+		c.Error(apierror.Validation(err.Error()))
+		return
+	}
+	updated.ID = c.Param("id")
+
+	saved, err := db.Update(updated)
+	if err != nil {
+		c.Error(toAPIError(err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, saved)
 }
 
+// bookPatch carries only the fields a caller wants to change; pointers let
+// us tell "field omitted" apart from "field set to its zero value".
+type bookPatch struct {
+	Title    *string `json:"title"`
+	Author   *string `json:"author"`
+	Quantity *int    `json:"quantity" binding:"omitempty,min=0"`
+}
+
+// patchBook godoc
+// @Summary Partially update a book
+// @Description Updates the fields present in the body. Requires the admin role.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param patch body bookPatch true "Fields to update"
+// @Success 200 {object} store.Book
+// @Failure 404 {object} apierror.Error
+// @Router /books/{id} [patch]
+//
+// patchBook handles PATCH /books/:id, updating only the fields present in
+// the request body.
+func patchBook(c *gin.Context) {
+	id := c.Param("id")
+
+	existing, err := db.Get(id)
+	if err != nil {
+		c.Error(toAPIError(err))
+		return
+	}
+
+	var patch bookPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.Error(apierror.Validation(err.Error()))
+		return
+	}
+
+	if patch.Title != nil {
+		existing.Title = *patch.Title
+	}
+	if patch.Author != nil {
+		existing.Author = *patch.Author
+	}
+	if patch.Quantity != nil {
+		existing.Quantity = *patch.Quantity
+	}
+
+	saved, err := db.Update(existing)
+	if err != nil {
+		c.Error(toAPIError(err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, saved)
+}
+
+// deleteBook godoc
+// @Summary Delete a book
+// @Description Deletes a book. Requires the admin role.
+// @Tags books
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Success 200 {object} object
+// @Failure 404 {object} apierror.Error
+// @Router /books/{id} [delete]
+//
+// deleteBook handles DELETE /books/:id.
+func deleteBook(c *gin.Context) {
+	id := c.Param("id")
+	if err := db.Delete(id); err != nil {
+		c.Error(toAPIError(err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "delete success"})
+}
+
+// @title Go-Gin-Simple-REST-API
+// @version 1.0
+// @description A small REST API for managing a book catalog, with checkout/return tracking.
+// @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+//
 // setting up a gin router to direct http requests
 func main() {
-	router := gin.Default()
-
-	// define a "localhost:8080/books" endpoint
-	router.GET("/books", getBooks)
-	router.GET("books/:id", bookById)
-	router.POST("/books", createBook)
-	router.PATCH("/return", returnBook)
-	router.PATCH("/checkout", checkoutBook)
+	storeFlag := flag.String("store", os.Getenv("STORE_DSN"), `store backend: "memory" (default), "sqlite://path.db", or "postgres://..."`)
+	flag.Parse()
+
+	var err error
+	db, err = store.Open(*storeFlag)
+	if err != nil {
+		log.Fatalf("store: %v", err)
+	}
+	userDB = users.NewMemory()
+	if username, password := os.Getenv("ADMIN_USERNAME"), os.Getenv("ADMIN_PASSWORD"); username != "" && password != "" {
+		if err := users.SeedAdmin(userDB, username, password); err != nil {
+			log.Fatalf("admin seed: %v", err)
+		}
+	}
+
+	authCfg, err = auth.NewConfigFromEnv()
+	if err != nil {
+		log.Fatalf("auth: %v", err)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("logger: %v", err)
+	}
+	defer logger.Sync()
+
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		shutdown, err := telemetry.InitTracer(context.Background(), serviceName, endpoint)
+		if err != nil {
+			log.Fatalf("tracing: %v", err)
+		}
+		defer shutdown(context.Background())
+	}
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(otelgin.Middleware(serviceName))
+	router.Use(telemetry.Metrics())
+	router.Use(telemetry.RequestLogger(logger))
+	router.Use(apierror.Middleware())
+
+	router.GET("/healthz", healthzHandler)
+	router.GET("/readyz", readyzHandler)
+	router.GET("/metrics", gin.WrapH(telemetry.Handler()))
+
+	router.POST("/auth/register", registerUser)
+	router.POST("/auth/login", loginUser)
+
+	// discoverable contract: the raw spec and a browsable UI over it
+	router.GET("/openapi.json", openAPISpec)
+	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// anyone can browse the catalog
+	public := router.Group("/")
+	public.GET("/books", getBooks)
+	public.GET("books/:id", bookById)
+
+	// logged-in users can check books in and out
+	userRoutes := router.Group("/")
+	userRoutes.Use(auth.RequireRole(authCfg, users.RoleUser))
+	userRoutes.PATCH("/return", returnBook)
+	userRoutes.PATCH("/checkout", checkoutBook)
+
+	// only admins can change the catalog itself
+	admin := router.Group("/books")
+	admin.Use(auth.RequireRole(authCfg, users.RoleAdmin))
+	admin.POST("", createBook)
+	admin.PUT("/:id", updateBook)
+	admin.PATCH("/:id", patchBook)
+	admin.DELETE("/:id", deleteBook)
+
 	router.Run("localhost:8080")
 }
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultLimit and MaxLimit bound how many books a single List call can
+// return; ParseListParams enforces them so a client can't request the
+// entire catalog in one page.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// sortableFields are the only columns callers may sort by; anything else
+// is rejected rather than silently ignored.
+var sortableFields = map[string]bool{
+	"title":    true,
+	"author":   true,
+	"quantity": true,
+}
+
+// SortField is one comma-separated term of a `sort` query parameter, e.g.
+// "title" or "-quantity".
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListParams filters, sorts, and paginates a List call. SQL backends
+// translate it into WHERE/ORDER BY/LIMIT/OFFSET; the in-memory backend
+// applies it to the slice directly.
+type ListParams struct {
+	Limit  int
+	Offset int
+
+	Author string // substring match, case-insensitive
+	Title  string // substring match, case-insensitive
+
+	InStock *bool // nil means "don't filter on stock"
+
+	Sort []SortField
+}
+
+// ParseListParams builds a ListParams from a request's query string,
+// defaulting Limit to DefaultLimit and capping it at MaxLimit.
+func ParseListParams(q url.Values) (ListParams, error) {
+	params := ListParams{Limit: DefaultLimit}
+
+	if raw := q.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return ListParams{}, fmt.Errorf("invalid limit %q", raw)
+		}
+		if n > MaxLimit {
+			n = MaxLimit
+		}
+		params.Limit = n
+	}
+
+	if raw := q.Get("offset"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return ListParams{}, fmt.Errorf("invalid offset %q", raw)
+		}
+		params.Offset = n
+	}
+
+	params.Author = q.Get("author")
+	params.Title = q.Get("title")
+
+	if raw := q.Get("in_stock"); raw != "" {
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return ListParams{}, fmt.Errorf("invalid in_stock %q", raw)
+		}
+		params.InStock = &b
+	}
+
+	if raw := q.Get("sort"); raw != "" {
+		for _, term := range strings.Split(raw, ",") {
+			desc := strings.HasPrefix(term, "-")
+			field := strings.TrimPrefix(term, "-")
+			if !sortableFields[field] {
+				return ListParams{}, fmt.Errorf("invalid sort field %q", field)
+			}
+			params.Sort = append(params.Sort, SortField{Field: field, Desc: desc})
+		}
+	}
+
+	return params, nil
+}
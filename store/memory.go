@@ -0,0 +1,173 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Memory is an in-process Store backed by a slice and guarded by a
+// sync.RWMutex. It's the default backend: no setup required, but state is
+// lost on restart and isn't shared across instances.
+type Memory struct {
+	mu    sync.RWMutex
+	books []Book
+}
+
+// NewMemory returns a Memory store seeded with the given books.
+func NewMemory(seed []Book) *Memory {
+	books := make([]Book, len(seed))
+	copy(books, seed)
+	return &Memory{books: books}
+}
+
+// SeedBooks is the default dataset used when no other seed is supplied.
+var SeedBooks = []Book{
+	{ID: "1", Title: "In Search of Lost Time", Author: "Marcel Proust", Quantity: 2},
+	{ID: "2", Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Quantity: 5},
+	{ID: "3", Title: "War and Peace", Author: "Leo Tolstoy", Quantity: 6},
+}
+
+func (m *Memory) List(params ListParams) ([]Book, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matched []Book
+	for _, b := range m.books {
+		if params.Author != "" && !strings.Contains(strings.ToLower(b.Author), strings.ToLower(params.Author)) {
+			continue
+		}
+		if params.Title != "" && !strings.Contains(strings.ToLower(b.Title), strings.ToLower(params.Title)) {
+			continue
+		}
+		if params.InStock != nil && (b.Quantity > 0) != *params.InStock {
+			continue
+		}
+		matched = append(matched, b)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		for _, s := range params.Sort {
+			less, equal := compareBookField(matched[i], matched[j], s.Field)
+			if equal {
+				continue
+			}
+			if s.Desc {
+				return !less
+			}
+			return less
+		}
+		return false
+	})
+
+	total := len(matched)
+
+	start := params.Offset
+	if start > total {
+		start = total
+	}
+	end := start + params.Limit
+	if end > total {
+		end = total
+	}
+
+	page := make([]Book, end-start)
+	copy(page, matched[start:end])
+	return page, total, nil
+}
+
+// compareBookField reports whether a sorts before b on field, and whether
+// they're equal on it (in which case the caller should fall through to the
+// next sort term).
+func compareBookField(a, b Book, field string) (less, equal bool) {
+	switch field {
+	case "title":
+		return a.Title < b.Title, a.Title == b.Title
+	case "author":
+		return a.Author < b.Author, a.Author == b.Author
+	case "quantity":
+		return a.Quantity < b.Quantity, a.Quantity == b.Quantity
+	default:
+		return false, true
+	}
+}
+
+func (m *Memory) Get(id string) (Book, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.find(id)
+}
+
+func (m *Memory) Create(b Book) (Book, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.books = append(m.books, b)
+	return b, nil
+}
+
+func (m *Memory) Update(b Book) (Book, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.books {
+		if m.books[i].ID == b.ID {
+			m.books[i] = b
+			return b, nil
+		}
+	}
+	return Book{}, ErrNotFound
+}
+
+func (m *Memory) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.books {
+		if m.books[i].ID == id {
+			m.books = append(m.books[:i], m.books[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *Memory) Checkout(id string) (Book, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.books {
+		if m.books[i].ID == id {
+			if m.books[i].Quantity <= 0 {
+				return Book{}, ErrOutOfStock
+			}
+			m.books[i].Quantity--
+			return m.books[i], nil
+		}
+	}
+	return Book{}, ErrNotFound
+}
+
+func (m *Memory) Return(id string) (Book, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.books {
+		if m.books[i].ID == id {
+			m.books[i].Quantity++
+			return m.books[i], nil
+		}
+	}
+	return Book{}, ErrNotFound
+}
+
+// find must be called with mu held (read or write).
+func (m *Memory) find(id string) (Book, error) {
+	for _, b := range m.books {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return Book{}, ErrNotFound
+}
@@ -0,0 +1,269 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Driver identifies which SQL dialect a SQL store is talking to. The two
+// backends differ only in placeholder syntax and locking clause; their
+// migrations are the same statement.
+type Driver string
+
+const (
+	DriverSQLite   Driver = "sqlite"
+	DriverPostgres Driver = "postgres"
+)
+
+// migrations holds the CREATE TABLE statement for each driver. Both schemas
+// describe the same shape as the Book struct.
+var migrations = map[Driver]string{
+	DriverSQLite: `
+CREATE TABLE IF NOT EXISTS books (
+	id       TEXT PRIMARY KEY,
+	title    TEXT NOT NULL,
+	author   TEXT NOT NULL,
+	quantity INTEGER NOT NULL DEFAULT 0
+);`,
+	DriverPostgres: `
+CREATE TABLE IF NOT EXISTS books (
+	id       TEXT PRIMARY KEY,
+	title    TEXT NOT NULL,
+	author   TEXT NOT NULL,
+	quantity INTEGER NOT NULL DEFAULT 0
+);`,
+}
+
+// SQL is a Store backed by database/sql, supporting SQLite and Postgres via
+// the driver-specific placeholder and locking helpers below.
+type SQL struct {
+	db     *sql.DB
+	driver Driver
+}
+
+// NewSQL runs the migration for driver on db, and seeds the books table
+// if it's empty. The caller owns db's lifecycle (db.Close()).
+func NewSQL(driver Driver, db *sql.DB) (*SQL, error) {
+	stmt, ok := migrations[driver]
+	if !ok {
+		return nil, fmt.Errorf("store: unsupported driver %q", driver)
+	}
+	if _, err := db.Exec(stmt); err != nil {
+		return nil, fmt.Errorf("store: migrate: %w", err)
+	}
+
+	s := &SQL{db: db, driver: driver}
+	if err := s.seedIfEmpty(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *SQL) seedIfEmpty() error {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM books").Scan(&count); err != nil {
+		return fmt.Errorf("store: count: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	for _, b := range SeedBooks {
+		if _, err := s.Create(b); err != nil {
+			return fmt.Errorf("store: seed: %w", err)
+		}
+	}
+	return nil
+}
+
+// placeholder returns the positional placeholder for arg index i (1-based)
+// in this store's driver dialect.
+func (s *SQL) placeholder(i int) string {
+	if s.driver == DriverPostgres {
+		return fmt.Sprintf("$%d", i)
+	}
+	return "?"
+}
+
+func (s *SQL) List(params ListParams) ([]Book, int, error) {
+	where, args := s.listWhere(params)
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM books" + where
+	if err := s.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("store: list: count: %w", err)
+	}
+
+	query := "SELECT id, title, author, quantity FROM books" + where + s.listOrderBy(params.Sort)
+	query += fmt.Sprintf(" LIMIT %s OFFSET %s", s.placeholder(len(args)+1), s.placeholder(len(args)+2))
+	args = append(args, params.Limit, params.Offset)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("store: list: %w", err)
+	}
+	defer rows.Close()
+
+	var books []Book
+	for rows.Next() {
+		var b Book
+		if err := rows.Scan(&b.ID, &b.Title, &b.Author, &b.Quantity); err != nil {
+			return nil, 0, fmt.Errorf("store: list: scan: %w", err)
+		}
+		books = append(books, b)
+	}
+	return books, total, rows.Err()
+}
+
+// listWhere builds the WHERE clause (including the leading space) and its
+// argument list for params' Author/Title/InStock filters.
+func (s *SQL) listWhere(params ListParams) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if params.Author != "" {
+		args = append(args, "%"+strings.ToLower(params.Author)+"%")
+		clauses = append(clauses, fmt.Sprintf("LOWER(author) LIKE %s", s.placeholder(len(args))))
+	}
+	if params.Title != "" {
+		args = append(args, "%"+strings.ToLower(params.Title)+"%")
+		clauses = append(clauses, fmt.Sprintf("LOWER(title) LIKE %s", s.placeholder(len(args))))
+	}
+	if params.InStock != nil {
+		if *params.InStock {
+			clauses = append(clauses, "quantity > 0")
+		} else {
+			clauses = append(clauses, "quantity = 0")
+		}
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// listOrderBy translates Sort into an ORDER BY clause (including the
+// leading space), or "" if unsorted.
+func (s *SQL) listOrderBy(fields []SortField) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	terms := make([]string, len(fields))
+	for i, f := range fields {
+		dir := "ASC"
+		if f.Desc {
+			dir = "DESC"
+		}
+		terms[i] = fmt.Sprintf("%s %s", f.Field, dir)
+	}
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
+
+func (s *SQL) Get(id string) (Book, error) {
+	query := fmt.Sprintf("SELECT id, title, author, quantity FROM books WHERE id = %s", s.placeholder(1))
+	var b Book
+	err := s.db.QueryRow(query, id).Scan(&b.ID, &b.Title, &b.Author, &b.Quantity)
+	if err == sql.ErrNoRows {
+		return Book{}, ErrNotFound
+	}
+	if err != nil {
+		return Book{}, fmt.Errorf("store: get: %w", err)
+	}
+	return b, nil
+}
+
+func (s *SQL) Create(b Book) (Book, error) {
+	query := fmt.Sprintf(
+		"INSERT INTO books (id, title, author, quantity) VALUES (%s, %s, %s, %s)",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4),
+	)
+	if _, err := s.db.Exec(query, b.ID, b.Title, b.Author, b.Quantity); err != nil {
+		return Book{}, fmt.Errorf("store: create: %w", err)
+	}
+	return b, nil
+}
+
+func (s *SQL) Update(b Book) (Book, error) {
+	query := fmt.Sprintf(
+		"UPDATE books SET title = %s, author = %s, quantity = %s WHERE id = %s",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4),
+	)
+	res, err := s.db.Exec(query, b.Title, b.Author, b.Quantity, b.ID)
+	if err != nil {
+		return Book{}, fmt.Errorf("store: update: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return Book{}, ErrNotFound
+	}
+	return b, nil
+}
+
+func (s *SQL) Delete(id string) error {
+	query := fmt.Sprintf("DELETE FROM books WHERE id = %s", s.placeholder(1))
+	res, err := s.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("store: delete: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Checkout and Return run inside a transaction and lock the target row
+// (SELECT ... FOR UPDATE on Postgres; SQLite serializes writes itself) so
+// concurrent requests can't both observe the same quantity and double
+// decrement or increment it.
+func (s *SQL) Checkout(id string) (Book, error) {
+	return s.transition(id, func(b *Book) error {
+		if b.Quantity <= 0 {
+			return ErrOutOfStock
+		}
+		b.Quantity--
+		return nil
+	})
+}
+
+func (s *SQL) Return(id string) (Book, error) {
+	return s.transition(id, func(b *Book) error {
+		b.Quantity++
+		return nil
+	})
+}
+
+func (s *SQL) transition(id string, mutate func(b *Book) error) (Book, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Book{}, fmt.Errorf("store: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	selectQuery := fmt.Sprintf("SELECT id, title, author, quantity FROM books WHERE id = %s", s.placeholder(1))
+	if s.driver == DriverPostgres {
+		selectQuery += " FOR UPDATE"
+	}
+
+	var b Book
+	err = tx.QueryRow(selectQuery, id).Scan(&b.ID, &b.Title, &b.Author, &b.Quantity)
+	if err == sql.ErrNoRows {
+		return Book{}, ErrNotFound
+	}
+	if err != nil {
+		return Book{}, fmt.Errorf("store: transition: select: %w", err)
+	}
+
+	if err := mutate(&b); err != nil {
+		return Book{}, err
+	}
+
+	updateQuery := fmt.Sprintf("UPDATE books SET quantity = %s WHERE id = %s", s.placeholder(1), s.placeholder(2))
+	if _, err := tx.Exec(updateQuery, b.Quantity, b.ID); err != nil {
+		return Book{}, fmt.Errorf("store: transition: update: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Book{}, fmt.Errorf("store: transition: commit: %w", err)
+	}
+	return b, nil
+}
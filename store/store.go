@@ -0,0 +1,56 @@
+// Package store defines the persistence layer for books: the data it holds,
+// the operations the API needs, and the errors handlers translate into HTTP
+// responses. Two implementations are provided, Memory and SQL, so the server
+// can run with nothing installed or against a real database.
+package store
+
+import "errors"
+
+// ErrNotFound is returned when a book lookup by ID finds nothing.
+var ErrNotFound = errors.New("book not found")
+
+// ErrOutOfStock is returned when a checkout is attempted on a book with
+// zero quantity remaining.
+var ErrOutOfStock = errors.New("no more books left")
+
+// Book is the persisted representation of a book record. The binding tags
+// are enforced by Gin's validator when a Book is bound from a request body.
+type Book struct {
+	ID       string `json:"id"`
+	Title    string `json:"title" binding:"required"`
+	Author   string `json:"author" binding:"required"`
+	Quantity int    `json:"quantity" binding:"min=0"`
+}
+
+// Store is implemented by every supported backend. Checkout and Return must
+// be safe to call concurrently for the same book: a backend that can't
+// guarantee atomicity at the storage layer needs its own locking.
+type Store interface {
+	// List returns the books matching params, along with the total
+	// number of matches before Limit/Offset were applied (so callers
+	// can build pagination metadata).
+	List(params ListParams) (books []Book, total int, err error)
+
+	// Get returns the book with the given ID, or ErrNotFound.
+	Get(id string) (Book, error)
+
+	// Create persists a new book and returns the stored record.
+	Create(b Book) (Book, error)
+
+	// Update replaces every field of the book with the given ID, or
+	// returns ErrNotFound if it doesn't exist.
+	Update(b Book) (Book, error)
+
+	// Delete removes the book with the given ID, or returns ErrNotFound.
+	Delete(id string) error
+
+	// Checkout decrements the quantity of the book with the given ID by
+	// one. It returns ErrNotFound if the book doesn't exist and
+	// ErrOutOfStock if the quantity is already zero. The decrement is
+	// atomic: concurrent checkouts of the last copy can't both succeed.
+	Checkout(id string) (Book, error)
+
+	// Return increments the quantity of the book with the given ID by
+	// one, or returns ErrNotFound if it doesn't exist.
+	Return(id string) (Book, error)
+}
@@ -0,0 +1,46 @@
+// Package telemetry wires up the cross-cutting observability concerns —
+// structured request logging, Prometheus metrics, and OpenTelemetry
+// tracing — as Gin middleware the router can Use() alongside the handlers.
+package telemetry
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/DrewK11/Go-Gin-Simple-REST-API/auth"
+)
+
+// RequestIDHeader is both the inbound header checked for a caller-supplied
+// request id and the outbound header it's echoed on.
+const RequestIDHeader = "X-Request-Id"
+
+// RequestLogger returns middleware that logs one structured JSON line per
+// request via logger, with request id, method, path, status, latency, and
+// the authenticated user id (if any, set by auth.RequireRole).
+func RequestLogger(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+
+		userID, _ := c.Get(auth.ContextUserIDKey)
+
+		logger.Info("request",
+			zap.String("request_id", requestID),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.Any("user_id", userID),
+		)
+	}
+}
@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests, labeled by method, route, and status.",
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	httpRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds, labeled by method, route, and status.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	// BooksCheckedOutTotal counts successful checkouts; incremented by the
+	// checkout handler itself rather than this middleware, since that's a
+	// business event rather than an HTTP one.
+	BooksCheckedOutTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "books_checked_out_total",
+		Help: "Total number of books successfully checked out.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequestsTotal, httpRequestDuration, BooksCheckedOutTotal)
+}
+
+// Metrics returns middleware that records httpRequestsTotal and
+// httpRequestDuration for every request.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		httpRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler serves the accumulated metrics in Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
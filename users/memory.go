@@ -0,0 +1,51 @@
+package users
+
+import (
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Memory is an in-process Store guarded by a sync.RWMutex, the users
+// counterpart to store.Memory.
+type Memory struct {
+	mu    sync.RWMutex
+	users map[string]User // keyed by username
+}
+
+// NewMemory returns an empty Memory store.
+func NewMemory() *Memory {
+	return &Memory{users: make(map[string]User)}
+}
+
+func (m *Memory) Create(id, username, password, role string) (User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.users[username]; exists {
+		return User{}, ErrDuplicateUsername
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return User{}, err
+	}
+
+	u := User{ID: id, Username: username, PasswordHash: hash, Role: role}
+	m.users[username] = u
+	return u, nil
+}
+
+func (m *Memory) Authenticate(username, password string) (User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	u, ok := m.users[username]
+	if !ok {
+		return User{}, ErrInvalidCredentials
+	}
+	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) != nil {
+		return User{}, ErrInvalidCredentials
+	}
+	return u, nil
+}
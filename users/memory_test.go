@@ -0,0 +1,31 @@
+package users
+
+import "testing"
+
+func TestMemoryCreateAndAuthenticate(t *testing.T) {
+	m := NewMemory()
+
+	u, err := m.Create("1", "alice", "hunter2", RoleUser)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if u.PasswordHash == "hunter2" {
+		t.Fatal("Create stored the plaintext password instead of a hash")
+	}
+
+	if _, err := m.Create("2", "alice", "other", RoleUser); err != ErrDuplicateUsername {
+		t.Errorf("Create(duplicate) error = %v, want ErrDuplicateUsername", err)
+	}
+
+	if _, err := m.Authenticate("alice", "hunter2"); err != nil {
+		t.Errorf("Authenticate(correct password): %v", err)
+	}
+
+	if _, err := m.Authenticate("alice", "wrong"); err != ErrInvalidCredentials {
+		t.Errorf("Authenticate(wrong password) error = %v, want ErrInvalidCredentials", err)
+	}
+
+	if _, err := m.Authenticate("bob", "hunter2"); err != ErrInvalidCredentials {
+		t.Errorf("Authenticate(missing user) error = %v, want ErrInvalidCredentials", err)
+	}
+}
@@ -0,0 +1,15 @@
+package users
+
+import "github.com/google/uuid"
+
+// SeedAdmin ensures an admin account with the given username/password
+// exists in s, so a fresh deployment has at least one account that can
+// reach the admin-only book routes. It's a no-op if the username is
+// already taken (e.g. on restart).
+func SeedAdmin(s Store, username, password string) error {
+	_, err := s.Create(uuid.NewString(), username, password, RoleAdmin)
+	if err == ErrDuplicateUsername {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,25 @@
+package users
+
+import "testing"
+
+func TestSeedAdmin(t *testing.T) {
+	m := NewMemory()
+
+	if err := SeedAdmin(m, "admin", "hunter2"); err != nil {
+		t.Fatalf("SeedAdmin: %v", err)
+	}
+
+	u, err := m.Authenticate("admin", "hunter2")
+	if err != nil {
+		t.Fatalf("Authenticate(seeded admin): %v", err)
+	}
+	if u.Role != RoleAdmin {
+		t.Errorf("Role = %q, want %q", u.Role, RoleAdmin)
+	}
+
+	// re-seeding (e.g. on restart) must not error even though the
+	// username is already taken.
+	if err := SeedAdmin(m, "admin", "hunter2"); err != nil {
+		t.Errorf("SeedAdmin(again): %v", err)
+	}
+}
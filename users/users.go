@@ -0,0 +1,61 @@
+// Package users is the persistence layer for accounts: who can log in and
+// what role they hold. It mirrors the shape of the store package but keeps
+// its own Store interface since a user record and a book record have
+// nothing else in common.
+package users
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Role values accepted by auth.RequireRole.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// ErrNotFound reports that no user matches the given username. Neither
+// Store method returns it: Authenticate reports an unknown username as
+// ErrInvalidCredentials.
+var ErrNotFound = errors.New("user not found")
+
+// ErrDuplicateUsername is returned by Create when the username is already
+// taken.
+var ErrDuplicateUsername = errors.New("username already registered")
+
+// ErrInvalidCredentials is returned by Authenticate when the username
+// doesn't exist or the password doesn't match.
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// User is the persisted representation of an account. PasswordHash is a
+// bcrypt hash; the plaintext password is never stored.
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+	Role         string
+}
+
+// Store is implemented by every supported backend.
+type Store interface {
+	// Create hashes password and persists a new user with the given
+	// username and role. It returns ErrDuplicateUsername if the
+	// username is already taken.
+	Create(id, username, password, role string) (User, error)
+
+	// Authenticate returns the user with the given username if password
+	// matches its stored hash, or ErrInvalidCredentials otherwise.
+	Authenticate(username, password string) (User, error)
+}
+
+// hashPassword is shared by every Store implementation so they hash
+// consistently.
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}